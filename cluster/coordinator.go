@@ -0,0 +1,244 @@
+// Package cluster implements distributed coordinator/worker mode: a
+// coordinator dials a fleet of worker nodes, ships each of them the parsed
+// test config, starts them within a few milliseconds of each other via a
+// synchronized Start RPC, and merges their streamed per-second stats and
+// final results into a single aggregated run.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/OriModai/http_blaster/cluster/clusterpb"
+)
+
+// start_skew is how far in the future the coordinator schedules the
+// synchronized start, giving every worker's Start RPC time to land before
+// the agreed wall-clock instant arrives.
+const start_skew = 2 * time.Second
+
+// HistogramSnapshot is the raw bucket counts of a main.Histogram, carried
+// over the wire since cluster (an importable package) cannot depend on
+// package main. run_coordinator_mode (cluster_runner.go) folds these
+// counts back into a real Histogram via histogram_from_buckets.
+type HistogramSnapshot []uint64
+
+// WorkerResult is one worker's contribution to the aggregated run, merged
+// by MergeResults into the totals that run_coordinator_mode passes to
+// write_report. The Overall/Get/PutRequests, ErrorCount and *Welford* fields
+// come from the RunSummary a worker attaches to its final StatsUpdate;
+// they're zero if the worker disconnected before sending one.
+type WorkerResult struct {
+	Addr            string
+	GetIops         uint64
+	PutIops         uint64
+	Errors          uint64
+	GetLatencyMin   time.Duration
+	GetLatencyMax   time.Duration
+	PutLatencyMin   time.Duration
+	PutLatencyMax   time.Duration
+	GetHistogram    HistogramSnapshot
+	PutHistogram    HistogramSnapshot
+	OverallRequests uint64
+	GetRequests     uint64
+	PutRequests     uint64
+	ErrorCount      uint64
+	GetWelfordCount uint64
+	GetWelfordMean  float64
+	GetWelfordM2    float64
+	PutWelfordCount uint64
+	PutWelfordMean  float64
+	PutWelfordM2    float64
+}
+
+// Coordinator drives a fleet of worker nodes for one test run.
+type Coordinator struct {
+	addrs []string
+}
+
+func NewCoordinator(workerAddrs []string) *Coordinator {
+	return &Coordinator{addrs: workerAddrs}
+}
+
+// Run configures every worker, starts them all within start_skew of the
+// same wall-clock instant, streams their stats for the duration of the
+// run, and returns one WorkerResult per worker for MergeResults to merge.
+func (c *Coordinator) Run(ctx context.Context, tomlConfigBytes []byte, dataSeed int64) ([]WorkerResult, error) {
+	conns := make([]*grpc.ClientConn, len(c.addrs))
+	clients := make([]clusterpb.ClusterServiceClient, len(c.addrs))
+	for i, addr := range c.addrs {
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return nil, fmt.Errorf("dial worker %s: %w", addr, err)
+		}
+		defer conn.Close()
+		conns[i] = conn
+		clients[i] = clusterpb.NewClusterServiceClient(conn)
+	}
+
+	for i, client := range clients {
+		resp, err := client.Configure(ctx, &clusterpb.ConfigureRequest{
+			TomlConfig: tomlConfigBytes,
+			DataSeed:   dataSeed,
+		})
+		if err != nil || !resp.Ok {
+			return nil, fmt.Errorf("configure worker %s: %v %v", c.addrs[i], err, resp)
+		}
+	}
+
+	start_at := time.Now().Add(start_skew)
+	for i, client := range clients {
+		resp, err := client.Start(ctx, &clusterpb.StartRequest{StartAtUnixNano: start_at.UnixNano()})
+		if err != nil || !resp.Ok {
+			return nil, fmt.Errorf("start worker %s: %v %v", c.addrs[i], err, resp)
+		}
+	}
+
+	results := make([]WorkerResult, len(clients))
+	var wg sync.WaitGroup
+	wg.Add(len(clients))
+	for i, client := range clients {
+		go func(i int, client clusterpb.ClusterServiceClient) {
+			defer wg.Done()
+			results[i] = c.stream_worker_stats(ctx, c.addrs[i], client)
+		}(i, client)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func (c *Coordinator) stream_worker_stats(ctx context.Context, addr string, client clusterpb.ClusterServiceClient) WorkerResult {
+	result := WorkerResult{Addr: addr}
+
+	stream, err := client.StreamStats(ctx, &clusterpb.StreamStatsRequest{})
+	if err != nil {
+		result.Errors++
+		return result
+	}
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return result
+		}
+		result.GetIops += update.GetIops
+		result.PutIops += update.PutIops
+		result.Errors += update.Errors
+		if update.Done {
+			if s := update.Summary; s != nil {
+				result.GetLatencyMin = time.Duration(s.GetLatencyMinNanos)
+				result.GetLatencyMax = time.Duration(s.GetLatencyMaxNanos)
+				result.PutLatencyMin = time.Duration(s.PutLatencyMinNanos)
+				result.PutLatencyMax = time.Duration(s.PutLatencyMaxNanos)
+				result.GetHistogram = HistogramSnapshot(s.GetHistogramBuckets)
+				result.PutHistogram = HistogramSnapshot(s.PutHistogramBuckets)
+				result.OverallRequests = s.OverallRequests
+				result.GetRequests = s.GetRequests
+				result.PutRequests = s.PutRequests
+				result.ErrorCount = s.ErrorCount
+				result.GetWelfordCount = s.GetWelfordCount
+				result.GetWelfordMean = s.GetWelfordMean
+				result.GetWelfordM2 = s.GetWelfordM2
+				result.PutWelfordCount = s.PutWelfordCount
+				result.PutWelfordMean = s.PutWelfordMean
+				result.PutWelfordM2 = s.PutWelfordM2
+			}
+			return result
+		}
+	}
+}
+
+// MergedResult is the fleet-wide rollup of every worker's WorkerResult,
+// shaped so run_coordinator_mode (cluster_runner.go) can build an AggregateResult
+// from it and hand it to the same write_report standalone runs use.
+type MergedResult struct {
+	GetIops, PutIops, Errs                    uint64
+	GetLatencyMin, GetLatencyMax              time.Duration
+	PutLatencyMin, PutLatencyMax              time.Duration
+	GetHistogram, PutHistogram                HistogramSnapshot
+	OverallRequests, GetRequests, PutRequests uint64
+	ErrorCount                                uint64
+	GetWelfordCount                           uint64
+	GetWelfordMean, GetWelfordM2              float64
+	PutWelfordCount                           uint64
+	PutWelfordMean, PutWelfordM2              float64
+}
+
+// MergeResults folds per-worker results into the aggregated totals
+// run_coordinator_mode needs: summing IOPS, request counts and error
+// counts, unioning latency histogram bucket counts, taking the min/max of
+// latency extrema across the whole fleet, and merging each worker's
+// running variance via the same parallel-merge formula main.welford_merge
+// uses (so the result is exact, not an average of averages).
+func MergeResults(workers []WorkerResult) MergedResult {
+	var m MergedResult
+	for _, w := range workers {
+		m.GetIops += w.GetIops
+		m.PutIops += w.PutIops
+		m.Errs += w.Errors
+		m.OverallRequests += w.OverallRequests
+		m.GetRequests += w.GetRequests
+		m.PutRequests += w.PutRequests
+		m.ErrorCount += w.ErrorCount
+
+		if m.GetLatencyMin == 0 || (w.GetLatencyMin != 0 && w.GetLatencyMin < m.GetLatencyMin) {
+			m.GetLatencyMin = w.GetLatencyMin
+		}
+		if w.GetLatencyMax > m.GetLatencyMax {
+			m.GetLatencyMax = w.GetLatencyMax
+		}
+		if m.PutLatencyMin == 0 || (w.PutLatencyMin != 0 && w.PutLatencyMin < m.PutLatencyMin) {
+			m.PutLatencyMin = w.PutLatencyMin
+		}
+		if w.PutLatencyMax > m.PutLatencyMax {
+			m.PutLatencyMax = w.PutLatencyMax
+		}
+
+		m.GetHistogram = histogram_snapshot_add(m.GetHistogram, w.GetHistogram)
+		m.PutHistogram = histogram_snapshot_add(m.PutHistogram, w.PutHistogram)
+
+		m.GetWelfordCount, m.GetWelfordMean, m.GetWelfordM2 = welford_merge_raw(
+			m.GetWelfordCount, m.GetWelfordMean, m.GetWelfordM2,
+			w.GetWelfordCount, w.GetWelfordMean, w.GetWelfordM2)
+		m.PutWelfordCount, m.PutWelfordMean, m.PutWelfordM2 = welford_merge_raw(
+			m.PutWelfordCount, m.PutWelfordMean, m.PutWelfordM2,
+			w.PutWelfordCount, w.PutWelfordMean, w.PutWelfordM2)
+	}
+	return m
+}
+
+// welford_merge_raw combines two Welford accumulators given as raw
+// (count, mean, m2) tuples, using the parallel variance combination
+// formula (Chan et al.). cluster can't depend on main.Welford, so it
+// operates on the tuple directly instead; main.welford_merge does the same
+// merge on the Welford type itself.
+func welford_merge_raw(dstCount uint64, dstMean, dstM2 float64, srcCount uint64, srcMean, srcM2 float64) (uint64, float64, float64) {
+	if srcCount == 0 {
+		return dstCount, dstMean, dstM2
+	}
+	if dstCount == 0 {
+		return srcCount, srcMean, srcM2
+	}
+	delta := srcMean - dstMean
+	total := dstCount + srcCount
+	m2 := dstM2 + srcM2 + delta*delta*float64(dstCount)*float64(srcCount)/float64(total)
+	mean := (dstMean*float64(dstCount) + srcMean*float64(srcCount)) / float64(total)
+	return total, mean, m2
+}
+
+func histogram_snapshot_add(dst, src HistogramSnapshot) HistogramSnapshot {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = make(HistogramSnapshot, len(src))
+	}
+	for i, c := range src {
+		dst[i] += c
+	}
+	return dst
+}
@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/OriModai/http_blaster/cluster/clusterpb"
+)
+
+// PipelineRunner runs http_blaster's existing standalone pipeline (parse
+// config, generate executors, run the test) against a config received over
+// gRPC instead of from -c, and reports per-second stats. The implementation
+// in package main sends those stats once the run has finished, not while it
+// is in progress.
+//
+// package main (cluster_runner.go) supplies the concrete implementation;
+// cluster only depends on
+// this interface so it never needs to import package main.
+type PipelineRunner interface {
+	// Configure parses the given TOML config bytes and seeds the shared
+	// data buffer deterministically from dataSeed, mirroring
+	// load_test_Config()'s use of the config-driven block size.
+	Configure(tomlConfigBytes []byte, dataSeed int64) error
+	// RunAt blocks the calling goroutine until startAt, then runs the test
+	// to completion.
+	RunAt(ctx context.Context, startAt time.Time) error
+	// StatsUpdates returns a channel of per-second stats, closed once the
+	// run (and any final flush) is complete.
+	StatsUpdates() <-chan *clusterpb.StatsUpdate
+}
+
+// WorkerServer implements clusterpb.ClusterServiceServer, accepting a
+// config and a synchronized start command from a coordinator and running
+// the existing http_blaster pipeline against it.
+type WorkerServer struct {
+	clusterpb.UnimplementedClusterServiceServer
+	runner PipelineRunner
+}
+
+func NewWorkerServer(runner PipelineRunner) *WorkerServer {
+	return &WorkerServer{runner: runner}
+}
+
+func (s *WorkerServer) Configure(ctx context.Context, req *clusterpb.ConfigureRequest) (*clusterpb.ConfigureResponse, error) {
+	if err := s.runner.Configure(req.TomlConfig, req.DataSeed); err != nil {
+		return &clusterpb.ConfigureResponse{Ok: false, Error: err.Error()}, nil
+	}
+	return &clusterpb.ConfigureResponse{Ok: true}, nil
+}
+
+func (s *WorkerServer) Start(ctx context.Context, req *clusterpb.StartRequest) (*clusterpb.StartResponse, error) {
+	startAt := time.Unix(0, req.StartAtUnixNano)
+	go func() {
+		if err := s.runner.RunAt(context.Background(), startAt); err != nil {
+			_ = err // surfaced to the coordinator as a zero-throughput worker result
+		}
+	}()
+	return &clusterpb.StartResponse{Ok: true}, nil
+}
+
+func (s *WorkerServer) StreamStats(req *clusterpb.StreamStatsRequest, stream clusterpb.ClusterService_StreamStatsServer) error {
+	for update := range s.runner.StatsUpdates() {
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serve starts a worker's gRPC listener and blocks until it returns an
+// error (typically from the listener being closed).
+func Serve(addr string, runner PipelineRunner) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	grpc_server := grpc.NewServer()
+	clusterpb.RegisterClusterServiceServer(grpc_server, NewWorkerServer(runner))
+	return grpc_server.Serve(lis)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/OriModai/http_blaster/cluster"
+	"github.com/OriModai/http_blaster/cluster/clusterpb"
+)
+
+// must_read_file reads the conf file's raw bytes so coordinator mode can
+// ship it to workers verbatim, rather than re-serializing the parsed
+// tomlConfig.
+func must_read_file(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal("failed to read conf file for coordinator mode: ", err)
+	}
+	return data
+}
+
+// pipeline_runner adapts http_blaster's existing standalone pipeline to
+// cluster.PipelineRunner, so a worker node can run it against a config
+// received over gRPC instead of from -c.
+type pipeline_runner struct {
+	stats chan *clusterpb.StatsUpdate
+}
+
+func new_pipeline_runner() *pipeline_runner {
+	return &pipeline_runner{stats: make(chan *clusterpb.StatsUpdate, 16)}
+}
+
+func (r *pipeline_runner) Configure(tomlConfigBytes []byte, dataSeed int64) error {
+	parsed, err := LoadConfigBytes(tomlConfigBytes)
+	if err != nil {
+		return err
+	}
+	config = parsed
+	seeded_rand := rand.New(rand.NewSource(dataSeed))
+	dataBfr = make([]byte, config.Global.Block_size)
+	for i := range dataBfr {
+		dataBfr[i] = byte(seeded_rand.Int())
+	}
+	return nil
+}
+
+func (r *pipeline_runner) RunAt(ctx context.Context, startAt time.Time) error {
+	defer close(r.stats)
+
+	if d := time.Until(startAt); d > 0 {
+		time.Sleep(d)
+	}
+
+	generate_executors()
+	start_executors(ctx)
+	wait_for_completion(ctx)
+
+	for _, bucket := range global_timeline.Sorted() {
+		r.stats <- &clusterpb.StatsUpdate{
+			Second:  bucket.Second,
+			GetIops: bucket.GetIops,
+			PutIops: bucket.PutIops,
+			Errors:  bucket.Errors,
+		}
+	}
+	r.stats <- &clusterpb.StatsUpdate{Done: true, Summary: run_summary(aggregate_executor_results(executors))}
+	return nil
+}
+
+func (r *pipeline_runner) StatsUpdates() <-chan *clusterpb.StatsUpdate {
+	return r.stats
+}
+
+// run_summary packs an AggregateResult into the RunSummary sent on the
+// final StatsUpdate, so the coordinator can merge histograms and variance
+// across the fleet exactly instead of just summing throughput.
+func run_summary(ar AggregateResult) *clusterpb.RunSummary {
+	getWelfordCount, getWelfordMean, getWelfordM2 := ar.GetVariance.State()
+	putWelfordCount, putWelfordMean, putWelfordM2 := ar.PutVariance.State()
+	return &clusterpb.RunSummary{
+		GetLatencyMinNanos:  int64(ar.GetLatMin),
+		GetLatencyMaxNanos:  int64(ar.GetLatMax),
+		PutLatencyMinNanos:  int64(ar.PutLatMin),
+		PutLatencyMaxNanos:  int64(ar.PutLatMax),
+		GetHistogramBuckets: ar.GetHistogram.Buckets(),
+		PutHistogramBuckets: ar.PutHistogram.Buckets(),
+		OverallRequests:     ar.OverallRequests,
+		GetRequests:         ar.GetRequests,
+		PutRequests:         ar.PutRequests,
+		ErrorCount:          uint64(len(ar.Errors)),
+		GetWelfordCount:     getWelfordCount,
+		GetWelfordMean:      getWelfordMean,
+		GetWelfordM2:        getWelfordM2,
+		PutWelfordCount:     putWelfordCount,
+		PutWelfordMean:      putWelfordMean,
+		PutWelfordM2:        putWelfordM2,
+	}
+}
+
+// run_worker_mode blocks serving gRPC requests from a coordinator until the
+// listener fails or the process is signalled to stop.
+func run_worker_mode() {
+	log.Println("Running in worker mode, listening on ", worker_bind)
+	if err := cluster.Serve(worker_bind, new_pipeline_runner()); err != nil {
+		log.Fatal("worker mode failed: ", err)
+	}
+}
+
+// run_coordinator_mode dials every configured worker, ships them the
+// parsed config and a shared data seed, starts them in sync, merges their
+// streamed results into one AggregateResult, and writes it out exactly as
+// report() would for a standalone run, so coordinator mode's results_file
+// and exit code reflect the whole fleet's errors, not just the
+// coordinator's own.
+func run_coordinator_mode(ctx context.Context, tomlConfigBytes []byte) int {
+	addrs := strings.Split(worker_addrs, ",")
+	log.Println("Running in coordinator mode against workers: ", addrs)
+
+	coordinator := cluster.NewCoordinator(addrs)
+	seed := rand.Int63()
+	start := time.Now()
+	results, err := coordinator.Run(ctx, tomlConfigBytes, seed)
+	if err != nil {
+		log.Fatal("coordinator run failed: ", err)
+	}
+
+	merged := cluster.MergeResults(results)
+	ar := AggregateResult{
+		OverallRequests: merged.OverallRequests,
+		GetRequests:     merged.GetRequests,
+		PutRequests:     merged.PutRequests,
+		OverallIops:     merged.GetIops + merged.PutIops,
+		GetIops:         merged.GetIops,
+		PutIops:         merged.PutIops,
+		GetLatMin:       merged.GetLatencyMin,
+		GetLatMax:       merged.GetLatencyMax,
+		PutLatMin:       merged.PutLatencyMin,
+		PutLatMax:       merged.PutLatencyMax,
+		GetHistogram:    histogram_from_buckets(merged.GetHistogram, merged.GetLatencyMin, merged.GetLatencyMax),
+		PutHistogram:    histogram_from_buckets(merged.PutHistogram, merged.PutLatencyMin, merged.PutLatencyMax),
+		GetVariance:     welford_from_state(merged.GetWelfordCount, merged.GetWelfordMean, merged.GetWelfordM2),
+		PutVariance:     welford_from_state(merged.PutWelfordCount, merged.PutWelfordMean, merged.PutWelfordM2),
+	}
+	if merged.GetRequests > 0 {
+		ar.GetAvgLat = time.Duration(merged.GetWelfordMean)
+	}
+	if merged.PutRequests > 0 {
+		ar.PutAvgLat = time.Duration(merged.PutWelfordMean)
+	}
+	for i := uint64(0); i < merged.ErrorCount; i++ {
+		ar.Errors = append(ar.Errors, fmt.Errorf("worker reported request failures"))
+	}
+
+	return write_report(ar, time.Since(start))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GlobalConfig holds the [global] section of the test config: the target
+// server and the run-wide knobs that aren't specific to any one workload.
+type GlobalConfig struct {
+	Server           string
+	Port             string
+	TSLMode          bool
+	Block_size       int
+	Duration         time.Duration
+	EnableHistograms bool `toml:"enable_histograms"`
+	MetricsPort      int  `toml:"metrics_port"`
+}
+
+// Workload describes one [workloads.<name>] section: what request to issue,
+// against what path, how many times, and how to pace it.
+type Workload struct {
+	Id       int32
+	Name     string `toml:"-"`
+	Type     CommandType
+	Path     string
+	Requests int
+	Schedule Schedule
+}
+
+// tomlConfig is the parsed form of the whole test config file.
+type tomlConfig struct {
+	Global    GlobalConfig
+	Workloads map[string]Workload
+}
+
+// LoadConfig parses the TOML config file at path.
+func LoadConfig(path string) (tomlConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tomlConfig{}, err
+	}
+	return LoadConfigBytes(data)
+}
+
+// LoadConfigBytes parses an in-memory TOML config, used by both LoadConfig
+// and worker mode, which receives its config over gRPC instead of reading
+// it from disk.
+func LoadConfigBytes(data []byte) (tomlConfig, error) {
+	var cfg tomlConfig
+	if _, err := toml.NewDecoder(bytes.NewReader(data)).Decode(&cfg); err != nil {
+		return tomlConfig{}, err
+	}
+	return cfg, nil
+}
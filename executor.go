@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Results is one executor's contribution to the overall report: aggregate
+// counters plus the latency histogram and running variance collected over
+// every request it issued while config.Global.EnableHistograms is set (both
+// stay empty otherwise).
+type Results struct {
+	Total     uint64
+	Iops      uint64
+	Avg       time.Duration
+	Max       time.Duration
+	Min       time.Duration
+	Histogram *Histogram
+	Variance  *Welford
+}
+
+// executor drives one workload: it issues Type requests against host:port
+// repeatedly until the context is cancelled or the workload's Requests
+// count is reached, recording every request's latency into its own
+// histogram and running variance when config.Global.EnableHistograms is set.
+type executor struct {
+	Workload  Workload
+	host      string
+	port      string
+	scheduler *Scheduler
+
+	client *http.Client
+
+	mu        sync.Mutex
+	total     uint64
+	errors    uint64
+	sumLat    time.Duration
+	maxLat    time.Duration
+	minLat    time.Duration
+	histogram *Histogram
+	variance  *Welford
+}
+
+func (e *executor) Start(ctx context.Context, wg *sync.WaitGroup) {
+	e.client = &http.Client{Timeout: 30 * time.Second}
+	e.histogram = NewHistogram()
+	e.variance = &Welford{}
+	go func() {
+		defer wg.Done()
+		e.run(ctx)
+	}()
+}
+
+// run dispatches requests according to the workload's Schedule: closed-loop
+// (as fast as the previous request completes) when the schedule is
+// ScheduleClosed, or paced off the Scheduler's intended-time tokens
+// otherwise. The scheduled intended time, not the moment the token was
+// read, is what gets passed to issue_request, so a worker running behind
+// schedule reports the backlog as latency instead of silently catching up.
+func (e *executor) run(ctx context.Context) {
+	tokens := e.scheduler.Run(ctx)
+	if tokens == nil {
+		e.run_closed_loop(ctx)
+		return
+	}
+
+	count := 0
+	for {
+		if e.Workload.Requests > 0 && count >= e.Workload.Requests {
+			return
+		}
+		select {
+		case intended, ok := <-tokens:
+			if !ok {
+				return
+			}
+			e.issue_request(intended)
+			count++
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *executor) run_closed_loop(ctx context.Context) {
+	count := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		if e.Workload.Requests > 0 && count >= e.Workload.Requests {
+			return
+		}
+		e.issue_request(time.Now())
+		count++
+	}
+}
+
+// issue_request performs a single HTTP request and records its latency,
+// measured from intended (the time the request was supposed to start, so
+// scheduled dispatch can report backpressure as latency rather than
+// hiding it) to completion.
+func (e *executor) issue_request(intended time.Time) {
+	global_metrics.InFlightInc()
+	defer global_metrics.InFlightDec()
+
+	url := fmt.Sprintf("http://%s:%s%s", e.host, e.port, e.Workload.Path)
+	method := string(e.Workload.Type)
+
+	var body io.Reader
+	if method == "PUT" || method == "POST" {
+		body = bytes.NewReader(dataBfr)
+	}
+
+	var err error
+	status := "error"
+	req, rerr := http.NewRequest(method, url, body)
+	if rerr != nil {
+		err = rerr
+	} else {
+		resp, derr := e.client.Do(req)
+		if derr != nil {
+			err = derr
+		} else {
+			resp.Body.Close()
+			status = strconv.Itoa(resp.StatusCode)
+		}
+	}
+
+	latency := time.Since(intended)
+
+	e.mu.Lock()
+	e.total++
+	if err != nil {
+		e.errors++
+	}
+	e.sumLat += latency
+	if e.minLat == 0 || latency < e.minLat {
+		e.minLat = latency
+	}
+	if latency > e.maxLat {
+		e.maxLat = latency
+	}
+	e.mu.Unlock()
+
+	if config.Global.EnableHistograms {
+		e.histogram.Record(latency)
+		e.variance.Push(float64(latency))
+	}
+	global_timeline.Record(time.Since(start_time), e.Workload.Type, err != nil)
+
+	global_metrics.IncRequest(e.Workload.Name, method, status)
+	if err != nil {
+		global_metrics.IncError(e.Workload.Name, method, status)
+	}
+	global_metrics.ObserveLatency(method, latency)
+}
+
+// Report returns the executor's aggregate Results, and a non-nil error if
+// any of its requests failed.
+func (e *executor) Report() (Results, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var avg time.Duration
+	if e.total > 0 {
+		avg = e.sumLat / time.Duration(e.total)
+	}
+
+	var err error
+	if e.errors > 0 {
+		err = fmt.Errorf("workload %d (%s): %d/%d requests failed", e.Workload.Id, e.Workload.Path, e.errors, e.total)
+	}
+
+	return Results{
+		Total:     e.total,
+		Iops:      e.total,
+		Avg:       avg,
+		Max:       e.maxLat,
+		Min:       e.minLat,
+		Histogram: e.histogram,
+		Variance:  e.variance,
+	}, err
+}
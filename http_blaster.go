@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,9 +9,11 @@ import (
 	"log"
 	"math/rand"
 	"os"
+	"os/signal"
 	"runtime/pprof"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -29,8 +32,17 @@ var (
 	ex_group     sync.WaitGroup
 	enable_log   bool
 	log_file     *os.File
+	interrupted  bool
+	run_mode     string
+	worker_addrs string
+	worker_bind  string
 )
 
+// shutdown_grace_period bounds how long wait_for_completion() waits for
+// in-flight requests to drain after a SIGINT/SIGTERM before giving up and
+// reporting whatever results are in hand.
+const shutdown_grace_period = 5 * time.Second
+
 const AppVersion = "1.0.0"
 
 type CommandType string
@@ -56,6 +68,9 @@ func init() {
 	flag.BoolVar(&cpu_profile, "p", false, "write cpu profile to file")
 	flag.BoolVar(&mem_profile, "m", false, "write mem profile to file")
 	flag.BoolVar(&enable_log, "d", false, "enable stdout to log")
+	flag.StringVar(&run_mode, "mode", "standalone", "run mode: standalone, coordinator or worker")
+	flag.StringVar(&worker_addrs, "workers", "", "comma-separated worker addresses (coordinator mode)")
+	flag.StringVar(&worker_bind, "bind", ":7878", "address to listen on (worker mode)")
 }
 
 func get_workload_id() int32 {
@@ -122,84 +137,104 @@ func generate_executors() {
 	for Name, workload := range config.Workloads {
 		log.Println("Adding executor for ", Name)
 		workload.Id = get_workload_id()
+		workload.Name = Name
 		e := &executor{Workload: workload, host: config.Global.Server,
-			port: config.Global.Port}
+			port: config.Global.Port, scheduler: NewScheduler(workload.Schedule)}
 		executors = append(executors, e)
 	}
 }
 
-func start_executors() {
+func start_executors(ctx context.Context) {
 	ex_group.Add(len(executors))
 	start_time = time.Now()
 	for _, e := range executors {
-		e.Start(&ex_group)
+		e.Start(ctx, &ex_group)
 	}
 }
 
-func wait_for_completion() {
-	log.Println("Wait for executors to finish")
-	ex_group.Wait()
-	end_time = time.Now()
+// setup_signal_handling returns a context that is cancelled on SIGINT or
+// SIGTERM, so executors can stop issuing new requests and drain in-flight
+// ones instead of the process simply dying mid-run.
+func setup_signal_handling() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig_chan := make(chan os.Signal, 1)
+	signal.Notify(sig_chan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig_chan
+		log.Println("Received interrupt, draining in-flight requests (grace period ", shutdown_grace_period, ")")
+		interrupted = true
+		cancel()
+	}()
+	return ctx
 }
 
-func report() int {
-	var overall_requests uint64 = 0
-	var overall_get_requests uint64 = 0
-	var overall_put_requests uint64 = 0
-	var overall_get_lat_max time.Duration = 0
-	var overall_get_lat_min time.Duration = 0
-	var overall_put_lat_max time.Duration = 0
-	var overall_put_lat_min time.Duration = 0
-	var overall_iops uint64 = 0
-	var overall_get_iops uint64 = 0
-	var overall_put_iops uint64 = 0
-	var overall_get_avg_lat time.Duration = 0
-	var overall_put_avg_lat time.Duration = 0
-	errors := make([]error, 0)
-	duration := end_time.Sub(start_time)
-	for _, executor := range executors {
-		results, err := executor.Report()
-		if err != nil {
-			errors = append(errors, err)
-		}
-		overall_requests += results.Total
-		if executor.Workload.Type == "GET" {
-			overall_get_requests += results.Total
-			overall_get_iops += results.Iops
-			overall_get_avg_lat += time.Duration(float64(results.Avg) * float64(results.Total))
-			if overall_get_lat_max < results.Max {
-				overall_get_lat_max = results.Max
-			}
-			if overall_get_lat_min == 0 {
-				overall_get_lat_min = results.Min
-			}
-			if overall_get_lat_min > results.Min {
-				overall_get_lat_min = results.Min
-			}
-		} else {
-			overall_put_requests += results.Total
-			overall_put_iops += results.Iops
-			overall_put_avg_lat += time.Duration(float64(results.Avg) * float64(results.Total))
-			if overall_put_lat_max < results.Max {
-				overall_put_lat_max = results.Max
-			}
-			if overall_put_lat_min == 0 {
-				overall_put_lat_min = results.Min
-			}
-			if overall_put_lat_min > results.Min {
-				overall_put_lat_min = results.Min
-			}
-		}
+// run_deadline_cancel releases the context.WithTimeout started by
+// apply_run_deadline, if any; it's a no-op until apply_run_deadline runs.
+var run_deadline_cancel context.CancelFunc = func() {}
 
-		overall_iops += results.Iops
+// apply_run_deadline bounds ctx by config.Global.Duration, the run's
+// documented overall timeout, so schedule-paced workloads (constant-rate,
+// Poisson, ramp) with no natural per-workload request count still stop on
+// their own instead of running until someone sends SIGINT by hand. A
+// Duration of zero leaves ctx unbounded.
+func apply_run_deadline(ctx context.Context) context.Context {
+	if config.Global.Duration <= 0 {
+		return ctx
 	}
+	var deadline_ctx context.Context
+	deadline_ctx, run_deadline_cancel = context.WithTimeout(ctx, config.Global.Duration)
+	return deadline_ctx
+}
 
-	if overall_get_requests != 0 {
-		overall_get_avg_lat = time.Duration(float64(overall_get_avg_lat) / float64(overall_get_requests))
-	}
-	if overall_put_requests != 0 {
-		overall_put_avg_lat = time.Duration(float64(overall_put_avg_lat) / float64(overall_put_requests))
+func wait_for_completion(ctx context.Context) {
+	log.Println("Wait for executors to finish")
+	done := make(chan struct{})
+	go func() {
+		ex_group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("Shutdown requested, waiting up to ", shutdown_grace_period, " for in-flight requests to drain")
+		select {
+		case <-done:
+		case <-time.After(shutdown_grace_period):
+			log.Println("Grace period expired with executors still draining; reporting partial results")
+		}
 	}
+	end_time = time.Now()
+}
+
+// report aggregates every executor's results and writes them to
+// results_file, for standalone runs. Coordinator mode builds its own
+// AggregateResult from merged worker summaries and calls write_report
+// directly instead, so the two modes share one report format.
+func report() int {
+	return write_report(aggregate_executor_results(executors), end_time.Sub(start_time))
+}
+
+// write_report logs and writes ar to results_file, returning the process
+// exit code: 0 on success, 2 if any executor reported errors.
+func write_report(ar AggregateResult, duration time.Duration) int {
+	overall_requests := ar.OverallRequests
+	overall_get_requests := ar.GetRequests
+	overall_put_requests := ar.PutRequests
+	overall_iops := ar.OverallIops
+	overall_get_iops := ar.GetIops
+	overall_put_iops := ar.PutIops
+	overall_get_avg_lat := ar.GetAvgLat
+	overall_put_avg_lat := ar.PutAvgLat
+	overall_get_lat_min := ar.GetLatMin
+	overall_get_lat_max := ar.GetLatMax
+	overall_put_lat_min := ar.PutLatMin
+	overall_put_lat_max := ar.PutLatMax
+	overall_get_histogram := ar.GetHistogram
+	overall_put_histogram := ar.PutHistogram
+	overall_get_variance := ar.GetVariance
+	overall_put_variance := ar.PutVariance
+	errors := ar.Errors
 
 	log.Println("Duration: ", duration)
 	log.Println("Overall Results: ")
@@ -215,6 +250,18 @@ func report() int {
 	log.Println("Overall IOPS: ", overall_iops)
 	log.Println("Overall GET IOPS: ", overall_get_iops)
 	log.Println("Overall PUT IOPS: ", overall_put_iops)
+	if global_sampler != nil {
+		peakCPU, peakRSS, avgLoad1 := global_sampler.Peaks()
+		log.Println("Peak CPU%: ", peakCPU)
+		log.Println("Peak RSS bytes: ", peakRSS)
+		log.Println("Average Load1: ", avgLoad1)
+	}
+	if config.Global.EnableHistograms {
+		log.Println("Overall GET Percentiles (p50/p90/p99/p99.9/p99.99): ", report_percentiles(overall_get_histogram))
+		log.Println("Overall GET StdDev: ", time.Duration(overall_get_variance.StdDev()))
+		log.Println("Overall PUT Percentiles (p50/p90/p99/p99.9/p99.99): ", report_percentiles(overall_put_histogram))
+		log.Println("Overall PUT StdDev: ", time.Duration(overall_put_variance.StdDev()))
+	}
 
 	f, err := os.Create(results_file)
 	defer f.Close()
@@ -223,6 +270,7 @@ func report() int {
 	}
 
 	f.WriteString(fmt.Sprintf("[global]\n"))
+	f.WriteString(fmt.Sprintf("interrupted=%v\n", interrupted))
 	f.WriteString(fmt.Sprintf("overall_requests=%v\n", overall_requests))
 	f.WriteString(fmt.Sprintf("overall_iops=%v\n", overall_iops))
 	f.WriteString(fmt.Sprintf("\n[get]\n"))
@@ -238,6 +286,29 @@ func report() int {
 	f.WriteString(fmt.Sprintf("overall_lat_max=%vusec\n", overall_put_lat_max.Nanoseconds()/1e3))
 	f.WriteString(fmt.Sprintf("overall_lat_avg=%vusec\n", overall_put_avg_lat.Nanoseconds()/1e3))
 
+	if global_sampler != nil {
+		global_sampler.WriteResultsSection(f)
+		if err := global_sampler.WriteCSV(results_file + ".system.csv"); err != nil {
+			log.Println("failed to write system sampler csv: ", err)
+		}
+	}
+
+	if config.Global.EnableHistograms {
+		f.WriteString(fmt.Sprintf("\n[get.percentiles]\n"))
+		write_percentiles(f, overall_get_histogram)
+		f.WriteString(fmt.Sprintf("stddev_usec=%v\n", int64(overall_get_variance.StdDev())/1e3))
+		f.WriteString(fmt.Sprintf("\n[put.percentiles]\n"))
+		write_percentiles(f, overall_put_histogram)
+		f.WriteString(fmt.Sprintf("stddev_usec=%v\n", int64(overall_put_variance.StdDev())/1e3))
+
+		if err := global_timeline.WriteCSV(results_file + ".timeline.csv"); err != nil {
+			log.Println("failed to write throughput timeline csv: ", err)
+		}
+		if err := global_timeline.WriteJSON(results_file + ".timeline.json"); err != nil {
+			log.Println("failed to write throughput timeline json: ", err)
+		}
+	}
+
 	if len(errors) > 0 {
 		for _, e := range errors {
 			log.Println(e)
@@ -247,6 +318,30 @@ func report() int {
 	return 0
 }
 
+var report_percentile_points = []float64{50, 90, 99, 99.9, 99.99}
+
+// report_percentiles formats the standard percentile set for a single
+// log.Println call.
+func report_percentiles(h *Histogram) string {
+	s := ""
+	for i, p := range report_percentile_points {
+		if i > 0 {
+			s += " / "
+		}
+		s += h.Percentile(p).String()
+	}
+	return s
+}
+
+// write_percentiles writes the standard percentile set into the results
+// file as p50/p90/p99/p99.9/p99.99 keys, in microseconds.
+func write_percentiles(f *os.File, h *Histogram) {
+	for _, p := range report_percentile_points {
+		key := fmt.Sprintf("p%v", p)
+		f.WriteString(fmt.Sprintf("%s_usec=%v\n", key, h.Percentile(p).Nanoseconds()/1e3))
+	}
+}
+
 func configure_log_to_file() {
 	if enable_log {
 		file_name := fmt.Sprintf("%s-loader.log", time.Now().Format("2006-01-02-15-04-05"))
@@ -290,12 +385,31 @@ func main() {
 	defer close_log_file()
 	defer stop_cpu_profile()
 	defer write_mem_profile()
+	defer func() { run_deadline_cancel() }()
+
+	if run_mode == "worker" {
+		run_worker_mode()
+		return
+	}
+
+	ctx := setup_signal_handling()
 
 	start_cpu_profile()
 	load_test_Config()
+	ctx = apply_run_deadline(ctx)
+
+	if run_mode == "coordinator" {
+		exit(run_coordinator_mode(ctx, must_read_file(conf_file)))
+		return
+	}
+
 	generate_executors()
-	start_executors()
-	wait_for_completion()
+	start_executors(ctx)
+	start_metrics_server()
+	start_system_sampler()
+	wait_for_completion(ctx)
+	stop_system_sampler()
+	stop_metrics_server()
 	err_code := report()
 	exit(err_code)
-}
\ No newline at end of file
+}
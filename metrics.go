@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metrics_key identifies one (workload, method, status) combination for the
+// request counter, matching the labels Prometheus scrapers expect.
+type metrics_key struct {
+	workload string
+	method   string
+	status   string
+}
+
+// Metrics holds the live counters, gauges and latency histograms exposed by
+// the /metrics endpoint while a test is running. It is safe for concurrent
+// use by every executor worker.
+type Metrics struct {
+	mu         sync.Mutex
+	requests   map[metrics_key]uint64
+	errors     map[metrics_key]uint64
+	inFlight   int64
+	histograms map[string]*Histogram
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:   make(map[metrics_key]uint64),
+		errors:     make(map[metrics_key]uint64),
+		histograms: make(map[string]*Histogram),
+	}
+}
+
+func (m *Metrics) IncRequest(workload, method, status string) {
+	key := metrics_key{workload, method, status}
+	m.mu.Lock()
+	m.requests[key]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) IncError(workload, method, status string) {
+	key := metrics_key{workload, method, status}
+	m.mu.Lock()
+	m.errors[key]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) ObserveLatency(method string, d time.Duration) {
+	m.mu.Lock()
+	h, ok := m.histograms[method]
+	if !ok {
+		h = NewHistogram()
+		m.histograms[method] = h
+	}
+	m.mu.Unlock()
+	h.Record(d)
+}
+
+func (m *Metrics) InFlightInc() {
+	m.mu.Lock()
+	m.inFlight++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) InFlightDec() {
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+}
+
+// writeExposition renders the current metrics snapshot in the Prometheus
+// text exposition format (no client_golang dependency, to keep http_blaster
+// free of extra build-time requirements).
+func (m *Metrics) writeExposition(w *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.WriteString("# HELP http_blaster_requests_total Total requests by workload, method and status\n")
+	w.WriteString("# TYPE http_blaster_requests_total counter\n")
+	for _, key := range sorted_metrics_keys(m.requests) {
+		fmt.Fprintf(w, "http_blaster_requests_total{workload=%q,method=%q,status=%q} %d\n",
+			key.workload, key.method, key.status, m.requests[key])
+	}
+
+	w.WriteString("# HELP http_blaster_errors_total Total errors by workload, method and status\n")
+	w.WriteString("# TYPE http_blaster_errors_total counter\n")
+	for _, key := range sorted_metrics_keys(m.errors) {
+		fmt.Fprintf(w, "http_blaster_errors_total{workload=%q,method=%q,status=%q} %d\n",
+			key.workload, key.method, key.status, m.errors[key])
+	}
+
+	w.WriteString("# HELP http_blaster_in_flight_requests Requests currently in flight\n")
+	w.WriteString("# TYPE http_blaster_in_flight_requests gauge\n")
+	fmt.Fprintf(w, "http_blaster_in_flight_requests %d\n", m.inFlight)
+
+	// quantile-labeled series make this a summary, not a histogram (which
+	// would need _bucket{le=...} series instead) - the TYPE line must
+	// match or conformance-checking scrapers flag it.
+	w.WriteString("# HELP http_blaster_request_latency_seconds Request latency by method\n")
+	w.WriteString("# TYPE http_blaster_request_latency_seconds summary\n")
+	methods := make([]string, 0, len(m.histograms))
+	for method := range m.histograms {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		h := m.histograms[method]
+		for _, p := range []float64{50, 90, 99, 99.9, 99.99} {
+			fmt.Fprintf(w, "http_blaster_request_latency_seconds{method=%q,quantile=%q} %f\n",
+				method, fmt.Sprintf("%v", p/100), h.Percentile(p).Seconds())
+		}
+		count, sum := h.Count(), h.Sum()
+		fmt.Fprintf(w, "http_blaster_request_latency_seconds_sum{method=%q} %f\n", method, sum.Seconds())
+		fmt.Fprintf(w, "http_blaster_request_latency_seconds_count{method=%q} %d\n", method, count)
+	}
+}
+
+func sorted_metrics_keys(m map[metrics_key]uint64) []metrics_key {
+	keys := make([]metrics_key, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].workload != keys[j].workload {
+			return keys[i].workload < keys[j].workload
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+	return keys
+}
+
+// MetricsServer serves the /metrics endpoint on a dedicated port for the
+// duration of a run, so Prometheus can scrape http_blaster while it is
+// still generating load instead of only after the final results_file.
+type MetricsServer struct {
+	server *http.Server
+}
+
+func NewMetricsServer(addr string, metrics *Metrics) *MetricsServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		var b strings.Builder
+		metrics.writeExposition(&b)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(b.String()))
+	})
+	return &MetricsServer{server: &http.Server{Addr: addr, Handler: mux}}
+}
+
+func (s *MetricsServer) Start() {
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Println("metrics server error: ", err)
+		}
+	}()
+}
+
+func (s *MetricsServer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		log.Println("metrics server shutdown error: ", err)
+	}
+}
+
+// global_metrics is populated by executors as requests complete and served
+// by the metrics server started from main().
+var global_metrics = NewMetrics()
+
+var metrics_server *MetricsServer
+
+func start_metrics_server() {
+	if config.Global.MetricsPort == 0 {
+		return
+	}
+	addr := fmt.Sprintf(":%d", config.Global.MetricsPort)
+	log.Println("Starting metrics endpoint on ", addr)
+	metrics_server = NewMetricsServer(addr, global_metrics)
+	metrics_server.Start()
+}
+
+func stop_metrics_server() {
+	if metrics_server != nil {
+		metrics_server.Stop()
+	}
+}
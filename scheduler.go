@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// ScheduleMode selects how a workload's requests are paced over time.
+type ScheduleMode string
+
+const (
+	// ScheduleClosed runs closed-loop: a worker issues its next request as
+	// soon as the previous one completes. This is http_blaster's original,
+	// implicit behavior and needs no Scheduler.
+	ScheduleClosed ScheduleMode = "closed"
+	// ScheduleConstantRate issues requests at a fixed target rate
+	// (requests/sec), independent of how long each request takes, so slow
+	// responses don't suppress the send rate (coordinated-omission-free).
+	ScheduleConstantRate ScheduleMode = "constant_rate"
+	// SchedulePoisson issues requests with exponentially distributed
+	// inter-arrival times around a mean rate, modelling bursty real traffic.
+	SchedulePoisson ScheduleMode = "poisson"
+	// ScheduleRamp linearly ramps the target rate from RateA to RateB over
+	// Duration, or walks through an explicit list of [duration, rate] Steps.
+	ScheduleRamp ScheduleMode = "ramp"
+)
+
+// ramp_rate_floor is the minimum instantaneous rate run_ramp will ever use.
+// A linear ramp that starts (or ends) at RateA/RateB == 0 is a normal way to
+// write a ramp-up-from-idle schedule; clamping to a small positive rate
+// instead of aborting keeps it issuing occasional requests and advancing
+// through the ramp rather than closing the token channel for the whole
+// window the instantaneous rate happens to be non-positive.
+const ramp_rate_floor = 0.1
+
+// RampStep is one [duration, rate] segment of a step schedule.
+type RampStep struct {
+	Duration time.Duration
+	Rate     float64
+}
+
+// Schedule configures the pacing of one workload. Mode selects the pacing
+// strategy; the remaining fields are only meaningful for the modes that use
+// them.
+type Schedule struct {
+	Mode     ScheduleMode
+	Rate     float64 // requests/sec, ScheduleConstantRate and SchedulePoisson
+	RateA    float64 // requests/sec at the start of a linear ramp
+	RateB    float64 // requests/sec at the end of a linear ramp
+	Duration time.Duration
+	Steps    []RampStep // explicit step schedule; overrides RateA/RateB/Duration when set
+}
+
+// Scheduler turns a Schedule into a stream of intended send times. Workers
+// read from the channel and dispatch a request per token; the token's
+// timestamp is the *intended* arrival time, which callers must use as the
+// latency measurement's start point instead of the actual dispatch time, so
+// that backpressure shows up as tail latency rather than being hidden by a
+// closed-loop request generator (coordinated omission).
+type Scheduler struct {
+	schedule Schedule
+}
+
+func NewScheduler(s Schedule) *Scheduler {
+	return &Scheduler{schedule: s}
+}
+
+// Run starts feeding intended send times into the returned channel until
+// ctx is cancelled or the schedule ends, then closes it: a ramp ends once
+// its Duration or Steps have run out, and constant_rate and poisson end at
+// once when Rate <= 0. For ScheduleClosed it returns a nil channel: callers
+// should fall back to their existing closed-loop dispatch in that case.
+func (sch *Scheduler) Run(ctx context.Context) <-chan time.Time {
+	if sch.schedule.Mode == ScheduleClosed || sch.schedule.Mode == "" {
+		return nil
+	}
+
+	out := make(chan time.Time)
+	go func() {
+		defer close(out)
+		switch sch.schedule.Mode {
+		case ScheduleConstantRate:
+			sch.run_constant_rate(ctx, out, sch.schedule.Rate)
+		case SchedulePoisson:
+			sch.run_poisson(ctx, out, sch.schedule.Rate)
+		case ScheduleRamp:
+			sch.run_ramp(ctx, out)
+		}
+	}()
+	return out
+}
+
+// run_constant_rate computes the intended send time of request i as
+// t0 + i/rate, so the schedule never drifts from a burst of slow requests -
+// a worker that falls behind simply sends its next token late, and that
+// lateness becomes part of the recorded latency.
+func (sch *Scheduler) run_constant_rate(ctx context.Context, out chan<- time.Time, rate float64) {
+	if rate <= 0 {
+		return
+	}
+	t0 := time.Now()
+	interval := time.Duration(float64(time.Second) / rate)
+	for i := 0; ; i++ {
+		intended := t0.Add(time.Duration(i) * interval)
+		if !scheduler_wait_until(ctx, intended) {
+			return
+		}
+		select {
+		case out <- intended:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run_poisson draws the next inter-arrival time from an exponential
+// distribution with mean 1/rate, giving Poisson-process arrivals.
+func (sch *Scheduler) run_poisson(ctx context.Context, out chan<- time.Time, rate float64) {
+	if rate <= 0 {
+		return
+	}
+	next := time.Now()
+	for {
+		interval := time.Duration(rand.ExpFloat64() / rate * float64(time.Second))
+		next = next.Add(interval)
+		if !scheduler_wait_until(ctx, next) {
+			return
+		}
+		select {
+		case out <- next:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run_ramp walks either an explicit step list or a linear interpolation from
+// RateA to RateB over Duration, re-deriving the instantaneous target rate as
+// it goes and delegating each segment to the constant-rate pacer.
+func (sch *Scheduler) run_ramp(ctx context.Context, out chan<- time.Time) {
+	t0 := time.Now()
+	if len(sch.schedule.Steps) > 0 {
+		for _, step := range sch.schedule.Steps {
+			deadline := time.Now().Add(step.Duration)
+			sch.run_constant_rate_until(ctx, out, step.Rate, deadline)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		return
+	}
+
+	total := sch.schedule.Duration
+	rateA, rateB := sch.schedule.RateA, sch.schedule.RateB
+	for i := 0; ; i++ {
+		elapsed := time.Since(t0)
+		if total > 0 && elapsed >= total {
+			return
+		}
+		frac := 0.0
+		if total > 0 {
+			frac = float64(elapsed) / float64(total)
+		}
+		rate := rateA + frac*(rateB-rateA)
+		if rate <= 0 {
+			rate = ramp_rate_floor
+		}
+		interval := time.Duration(float64(time.Second) / rate)
+		// Cap the wait to whatever's left of the ramp: a near-zero
+		// instantaneous rate (RateA: 0 is a normal ramp-up-from-idle
+		// config) would otherwise compute a near-infinite interval and
+		// the loop would never get a chance to re-derive a higher rate
+		// from a later, larger frac.
+		if total > 0 {
+			if remaining := total - elapsed; interval > remaining {
+				interval = remaining
+			}
+		}
+		intended := t0.Add(elapsed + interval)
+		if !scheduler_wait_until(ctx, intended) {
+			return
+		}
+		select {
+		case out <- intended:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (sch *Scheduler) run_constant_rate_until(ctx context.Context, out chan<- time.Time, rate float64, deadline time.Time) {
+	if rate <= 0 {
+		scheduler_wait_until(ctx, deadline)
+		return
+	}
+	interval := time.Duration(float64(time.Second) / rate)
+	for t := time.Now(); t.Before(deadline); t = t.Add(interval) {
+		if !scheduler_wait_until(ctx, t) {
+			return
+		}
+		select {
+		case out <- t:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scheduler_wait_until blocks until deadline or ctx cancellation, returning
+// false in the latter case so callers can stop cleanly.
+func scheduler_wait_until(ctx context.Context, deadline time.Time) bool {
+	d := time.Until(deadline)
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSchedulerClosedReturnsNilChannel(t *testing.T) {
+	sch := NewScheduler(Schedule{Mode: ScheduleClosed})
+	if ch := sch.Run(context.Background()); ch != nil {
+		t.Errorf("Run() = %v, want nil channel for ScheduleClosed", ch)
+	}
+}
+
+func TestSchedulerConstantRateSpacing(t *testing.T) {
+	const rate = 50.0 // req/sec -> 20ms apart
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+
+	sch := NewScheduler(Schedule{Mode: ScheduleConstantRate, Rate: rate})
+	ch := sch.Run(ctx)
+
+	var tokens []time.Time
+	for tok := range ch {
+		tokens = append(tokens, tok)
+	}
+
+	if len(tokens) < 2 {
+		t.Fatalf("got %d tokens, want at least 2", len(tokens))
+	}
+	want := time.Duration(float64(time.Second) / rate)
+	for i := 1; i < len(tokens); i++ {
+		got := tokens[i].Sub(tokens[i-1])
+		if got != want {
+			t.Errorf("token %d spacing = %v, want exactly %v (intended times, not wall clock)", i, got, want)
+		}
+	}
+}
+
+func TestSchedulerPoissonMeanRate(t *testing.T) {
+	const rate = 200.0 // req/sec -> mean interval 5ms
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	sch := NewScheduler(Schedule{Mode: SchedulePoisson, Rate: rate})
+	ch := sch.Run(ctx)
+
+	var tokens []time.Time
+	for tok := range ch {
+		tokens = append(tokens, tok)
+	}
+
+	if len(tokens) < 10 {
+		t.Fatalf("got %d tokens, want at least 10 to estimate a mean", len(tokens))
+	}
+	var total time.Duration
+	for i := 1; i < len(tokens); i++ {
+		total += tokens[i].Sub(tokens[i-1])
+	}
+	gotMean := total / time.Duration(len(tokens)-1)
+	wantMean := time.Duration(float64(time.Second) / rate)
+	// Exponential inter-arrivals are high-variance; allow a generous band
+	// around the target mean rather than pinning an exact value.
+	if gotMean < wantMean/3 || gotMean > wantMean*3 {
+		t.Errorf("mean inter-arrival = %v, want within 3x of %v", gotMean, wantMean)
+	}
+}
+
+func TestSchedulerRampIncreasesRate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	sch := NewScheduler(Schedule{
+		Mode:     ScheduleRamp,
+		RateA:    20,
+		RateB:    200,
+		Duration: 300 * time.Millisecond,
+	})
+	ch := sch.Run(ctx)
+
+	var tokens []time.Time
+	for tok := range ch {
+		tokens = append(tokens, tok)
+	}
+	if len(tokens) < 4 {
+		t.Fatalf("got %d tokens, want enough to compare early/late spacing", len(tokens))
+	}
+
+	half := len(tokens) / 2
+	firstGap := tokens[1].Sub(tokens[0])
+	lastGap := tokens[len(tokens)-1].Sub(tokens[len(tokens)-2])
+	_ = half
+	if lastGap >= firstGap {
+		t.Errorf("last inter-token gap (%v) should be shorter than the first (%v) as the ramp speeds up", lastGap, firstGap)
+	}
+}
+
+func TestSchedulerRampFromIdleStillIssuesTokens(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	sch := NewScheduler(Schedule{
+		Mode:     ScheduleRamp,
+		RateA:    0,
+		RateB:    200,
+		Duration: 200 * time.Millisecond,
+	})
+	ch := sch.Run(ctx)
+
+	var tokens []time.Time
+	for tok := range ch {
+		tokens = append(tokens, tok)
+	}
+	if len(tokens) == 0 {
+		t.Fatal("got 0 tokens for a ramp starting at RateA: 0, want the ramp to still make progress instead of closing immediately")
+	}
+}
+
+func TestSchedulerRampSteps(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	sch := NewScheduler(Schedule{
+		Mode: ScheduleRamp,
+		Steps: []RampStep{
+			{Duration: 60 * time.Millisecond, Rate: 100},
+			{Duration: 60 * time.Millisecond, Rate: 20},
+		},
+	})
+	ch := sch.Run(ctx)
+
+	var tokens []time.Time
+	for tok := range ch {
+		tokens = append(tokens, tok)
+	}
+	if len(tokens) < 3 {
+		t.Fatalf("got %d tokens, want at least a few across both steps", len(tokens))
+	}
+}
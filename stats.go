@@ -0,0 +1,435 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// histogram_low and histogram_high bound the latency range tracked by
+// Histogram, mirroring the value range HDR histograms typically use for
+// network request latencies (1us..60s).
+const (
+	histogram_low           = int64(time.Microsecond)
+	histogram_high          = int64(60 * time.Second)
+	histogram_sig_digits    = 3
+	histogram_buckets_per_2 = 1 << histogram_sig_digits
+)
+
+// Histogram is a log-linear latency histogram in the spirit of HdrHistogram:
+// values are bucketed so that, above histogram_buckets_per_2*histogram_low,
+// the relative error within a bucket never exceeds 1/histogram_buckets_per_2
+// (histogram_sig_digits significant binary digits) across the whole
+// 1us..60s range, while using a small, fixed number of buckets. Below that,
+// buckets are histogram_low wide.
+type Histogram struct {
+	mu      sync.Mutex
+	counts  []uint64
+	total   uint64
+	sum     int64
+	minSeen int64
+	maxSeen int64
+}
+
+func NewHistogram() *Histogram {
+	buckets := histogram_bucket_index(histogram_high) + 1
+	return &Histogram{
+		counts:  make([]uint64, buckets),
+		minSeen: math.MaxInt64,
+	}
+}
+
+func histogram_bucket_index(value int64) int {
+	if value < histogram_low {
+		value = histogram_low
+	}
+	// each "decade" (power of 2 in value) is split into histogram_buckets_per_2
+	// linear sub-buckets, giving log-linear resolution.
+	decade := 0
+	v := value / histogram_low
+	for v >= 2*histogram_buckets_per_2 {
+		v >>= 1
+		decade++
+	}
+	return decade*histogram_buckets_per_2 + int(v)
+}
+
+func (h *Histogram) Record(d time.Duration) {
+	v := int64(d)
+	if v > histogram_high {
+		v = histogram_high
+	}
+	idx := histogram_bucket_index(v)
+	h.mu.Lock()
+	h.counts[idx]++
+	h.total++
+	h.sum += v
+	if v < h.minSeen {
+		h.minSeen = v
+	}
+	if v > h.maxSeen {
+		h.maxSeen = v
+	}
+	h.mu.Unlock()
+}
+
+// Percentile returns the latency value at percentile p (0..100). The
+// returned duration is the upper edge of the bucket the percentile falls
+// into, so callers get a slight over-estimate rather than an under-estimate
+// of tail latency.
+func (h *Histogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.total == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p / 100 * float64(h.total)))
+	if target == 0 {
+		target = 1
+	}
+	var seen uint64
+	for idx, c := range h.counts {
+		seen += c
+		if seen >= target {
+			return histogram_bucket_upper_bound(idx)
+		}
+	}
+	return time.Duration(h.maxSeen)
+}
+
+// Count returns the number of samples recorded, and Sum their total
+// duration, so callers (e.g. the /metrics summary) can report _count/_sum
+// series alongside the quantiles.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.total
+}
+
+func (h *Histogram) Sum() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return time.Duration(h.sum)
+}
+
+func histogram_bucket_upper_bound(idx int) time.Duration {
+	decade := idx/histogram_buckets_per_2 - 1
+	sub := idx%histogram_buckets_per_2 + histogram_buckets_per_2
+	if decade < 0 {
+		decade, sub = 0, idx
+	}
+	value := int64(sub+1) << uint(decade)
+	return time.Duration(value * histogram_low)
+}
+
+// Buckets returns a copy of h's raw bucket counts, and Bounds its observed
+// min/max, so cluster mode can ship a worker's histogram to the coordinator
+// over the wire without exposing h's internal fields.
+func (h *Histogram) Buckets() []uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]uint64, len(h.counts))
+	copy(out, h.counts)
+	return out
+}
+
+func (h *Histogram) Bounds() (min, max time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.total == 0 {
+		return 0, 0
+	}
+	return time.Duration(h.minSeen), time.Duration(h.maxSeen)
+}
+
+// histogram_from_buckets rebuilds a Histogram from raw bucket counts
+// received over the wire, used by cluster mode to fold a worker's
+// RunSummary back into a real Histogram for write_report.
+func histogram_from_buckets(buckets []uint64, min, max time.Duration) *Histogram {
+	h := NewHistogram()
+	var total uint64
+	for i, c := range buckets {
+		if i >= len(h.counts) {
+			break
+		}
+		h.counts[i] = c
+		total += c
+	}
+	h.total = total
+	h.minSeen = int64(min)
+	h.maxSeen = int64(max)
+	return h
+}
+
+// Welford accumulates mean and variance of a stream of samples in a single
+// pass using Welford's online algorithm, avoiding the numerical instability
+// of naive sum-of-squares variance.
+type Welford struct {
+	mu    sync.Mutex
+	count uint64
+	mean  float64
+	m2    float64
+}
+
+func (w *Welford) Push(x float64) {
+	w.mu.Lock()
+	w.count++
+	delta := x - w.mean
+	w.mean += delta / float64(w.count)
+	delta2 := x - w.mean
+	w.m2 += delta * delta2
+	w.mu.Unlock()
+}
+
+func (w *Welford) Mean() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.mean
+}
+
+func (w *Welford) Variance() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.count < 2 {
+		return 0
+	}
+	return w.m2 / float64(w.count-1)
+}
+
+func (w *Welford) StdDev() float64 {
+	return math.Sqrt(w.Variance())
+}
+
+// State returns w's raw count/mean/m2, and welford_from_state rebuilds a
+// Welford from them, so cluster mode can ship a worker's running variance
+// to the coordinator over the wire and merge it back exactly via
+// welford_merge rather than re-deriving it from an average.
+func (w *Welford) State() (count uint64, mean, m2 float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count, w.mean, w.m2
+}
+
+func welford_from_state(count uint64, mean, m2 float64) *Welford {
+	return &Welford{count: count, mean: mean, m2: m2}
+}
+
+// SecondBucket holds the per-method request counts observed during a single
+// one-second window of the run, used to build the throughput timeline.
+type SecondBucket struct {
+	Second  int64  `json:"second"`
+	GetIops uint64 `json:"get_iops"`
+	PutIops uint64 `json:"put_iops"`
+	Errors  uint64 `json:"errors"`
+}
+
+// Timeline tracks per-second throughput for the duration of a run, keyed by
+// the number of whole seconds elapsed since start_time.
+type Timeline struct {
+	mu      sync.Mutex
+	buckets map[int64]*SecondBucket
+}
+
+func NewTimeline() *Timeline {
+	return &Timeline{buckets: make(map[int64]*SecondBucket)}
+}
+
+func (t *Timeline) Record(elapsed time.Duration, cmd CommandType, isErr bool) {
+	second := int64(elapsed / time.Second)
+	t.mu.Lock()
+	b, ok := t.buckets[second]
+	if !ok {
+		b = &SecondBucket{Second: second}
+		t.buckets[second] = b
+	}
+	if isErr {
+		b.Errors++
+	}
+	switch cmd {
+	case GET:
+		b.GetIops++
+	default:
+		b.PutIops++
+	}
+	t.mu.Unlock()
+}
+
+// Sorted returns the recorded buckets ordered by elapsed second, with any
+// gaps left empty (a quiet second produces no entry).
+func (t *Timeline) Sorted() []SecondBucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]SecondBucket, 0, len(t.buckets))
+	for _, b := range t.buckets {
+		out = append(out, *b)
+	}
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1].Second > out[j].Second; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+func (t *Timeline) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	f.WriteString("second,get_iops,put_iops,errors\n")
+	for _, b := range t.Sorted() {
+		f.WriteString(fmt.Sprintf("%d,%d,%d,%d\n", b.Second, b.GetIops, b.PutIops, b.Errors))
+	}
+	return nil
+}
+
+func (t *Timeline) WriteJSON(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t.Sorted())
+}
+
+// global_timeline collects per-second throughput for the whole run; it is
+// populated by executors as they issue requests and drained by report().
+var global_timeline = NewTimeline()
+
+// histogram_merge folds src's bucket counts into dst, used by report() to
+// combine per-executor histograms into the overall GET/PUT histograms.
+func histogram_merge(dst, src *Histogram) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+	for i, c := range src.counts {
+		dst.counts[i] += c
+	}
+	dst.total += src.total
+	dst.sum += src.sum
+	if src.minSeen < dst.minSeen {
+		dst.minSeen = src.minSeen
+	}
+	if src.maxSeen > dst.maxSeen {
+		dst.maxSeen = src.maxSeen
+	}
+}
+
+// AggregateResult is the GET/PUT rollup of every executor's Results,
+// computed once by aggregate_executor_results and shared by both the
+// standalone report() and worker mode, which packs the same numbers into
+// its RunSummary. Coordinator mode builds one from merged worker summaries
+// instead.
+type AggregateResult struct {
+	OverallRequests uint64
+	GetRequests     uint64
+	PutRequests     uint64
+	OverallIops     uint64
+	GetIops         uint64
+	PutIops         uint64
+	GetAvgLat       time.Duration
+	PutAvgLat       time.Duration
+	GetLatMin       time.Duration
+	GetLatMax       time.Duration
+	PutLatMin       time.Duration
+	PutLatMax       time.Duration
+	GetHistogram    *Histogram
+	PutHistogram    *Histogram
+	GetVariance     *Welford
+	PutVariance     *Welford
+	Errors          []error
+}
+
+// aggregate_executor_results folds every executor's Report() into one
+// GET/PUT rollup: summed IOPS, weighted average latency, min/max extrema,
+// and merged histograms/variance.
+func aggregate_executor_results(execs []*executor) AggregateResult {
+	ar := AggregateResult{
+		GetHistogram: NewHistogram(),
+		PutHistogram: NewHistogram(),
+		GetVariance:  &Welford{},
+		PutVariance:  &Welford{},
+		Errors:       make([]error, 0),
+	}
+
+	for _, e := range execs {
+		results, err := e.Report()
+		if err != nil {
+			ar.Errors = append(ar.Errors, err)
+		}
+		ar.OverallRequests += results.Total
+		ar.OverallIops += results.Iops
+
+		if e.Workload.Type == "GET" {
+			ar.GetRequests += results.Total
+			ar.GetIops += results.Iops
+			ar.GetAvgLat += time.Duration(float64(results.Avg) * float64(results.Total))
+			if ar.GetLatMax < results.Max {
+				ar.GetLatMax = results.Max
+			}
+			if ar.GetLatMin == 0 || ar.GetLatMin > results.Min {
+				ar.GetLatMin = results.Min
+			}
+			if results.Histogram != nil {
+				histogram_merge(ar.GetHistogram, results.Histogram)
+			}
+			if results.Variance != nil {
+				welford_merge(ar.GetVariance, results.Variance)
+			}
+		} else {
+			ar.PutRequests += results.Total
+			ar.PutIops += results.Iops
+			ar.PutAvgLat += time.Duration(float64(results.Avg) * float64(results.Total))
+			if ar.PutLatMax < results.Max {
+				ar.PutLatMax = results.Max
+			}
+			if ar.PutLatMin == 0 || ar.PutLatMin > results.Min {
+				ar.PutLatMin = results.Min
+			}
+			if results.Histogram != nil {
+				histogram_merge(ar.PutHistogram, results.Histogram)
+			}
+			if results.Variance != nil {
+				welford_merge(ar.PutVariance, results.Variance)
+			}
+		}
+	}
+
+	if ar.GetRequests != 0 {
+		ar.GetAvgLat = time.Duration(float64(ar.GetAvgLat) / float64(ar.GetRequests))
+	}
+	if ar.PutRequests != 0 {
+		ar.PutAvgLat = time.Duration(float64(ar.PutAvgLat) / float64(ar.PutRequests))
+	}
+	return ar
+}
+
+// welford_merge combines src into dst using the parallel variance
+// combination formula (Chan et al.), so per-executor running variance can be
+// merged into the overall GET/PUT variance without re-visiting samples.
+func welford_merge(dst, src *Welford) {
+	src.mu.Lock()
+	srcCount, srcMean, srcM2 := src.count, src.mean, src.m2
+	src.mu.Unlock()
+	if srcCount == 0 {
+		return
+	}
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+	if dst.count == 0 {
+		dst.count, dst.mean, dst.m2 = srcCount, srcMean, srcM2
+		return
+	}
+	delta := srcMean - dst.mean
+	total := dst.count + srcCount
+	dst.m2 = dst.m2 + srcM2 + delta*delta*float64(dst.count)*float64(srcCount)/float64(total)
+	dst.mean = (dst.mean*float64(dst.count) + srcMean*float64(srcCount)) / float64(total)
+	dst.count = total
+}
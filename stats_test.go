@@ -0,0 +1,140 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHistogramBucketIndexMonotonic(t *testing.T) {
+	prev := -1
+	for us := 1; us <= 60*1e6; us *= 2 {
+		idx := histogram_bucket_index(int64(us) * int64(time.Microsecond))
+		if idx <= prev {
+			t.Fatalf("histogram_bucket_index(%dus) = %d, want > %d (previous)", us, idx, prev)
+		}
+		prev = idx
+	}
+}
+
+func TestHistogramBucketIndexClampsBelowLow(t *testing.T) {
+	if got, want := histogram_bucket_index(0), histogram_bucket_index(histogram_low); got != want {
+		t.Errorf("histogram_bucket_index(0) = %d, want %d (clamped to histogram_low)", got, want)
+	}
+}
+
+func TestHistogramPercentileWithinRelativeError(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	p50 := h.Percentile(50)
+	want := 500 * time.Millisecond
+	if rel := math.Abs(float64(p50-want)) / float64(want); rel > 1.0/histogram_buckets_per_2 {
+		t.Errorf("Percentile(50) = %v, want within %.3f relative error of %v", p50, 1.0/histogram_buckets_per_2, want)
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a := NewHistogram()
+	b := NewHistogram()
+	a.Record(1 * time.Millisecond)
+	b.Record(2 * time.Millisecond)
+	b.Record(3 * time.Millisecond)
+
+	histogram_merge(a, b)
+
+	if a.total != 3 {
+		t.Errorf("merged total = %d, want 3", a.total)
+	}
+	if got := a.Percentile(100); got < 3*time.Millisecond {
+		t.Errorf("merged max percentile = %v, want >= 3ms", got)
+	}
+}
+
+func TestHistogramCountAndSum(t *testing.T) {
+	h := NewHistogram()
+	h.Record(1 * time.Millisecond)
+	h.Record(2 * time.Millisecond)
+	h.Record(3 * time.Millisecond)
+
+	if got := h.Count(); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+	if got := h.Sum(); got != 6*time.Millisecond {
+		t.Errorf("Sum() = %v, want 6ms", got)
+	}
+}
+
+func TestWelfordMatchesNaiveVariance(t *testing.T) {
+	samples := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	w := &Welford{}
+	for _, s := range samples {
+		w.Push(s)
+	}
+
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	mean := sum / float64(len(samples))
+	var sumSq float64
+	for _, s := range samples {
+		sumSq += (s - mean) * (s - mean)
+	}
+	wantVariance := sumSq / float64(len(samples)-1)
+
+	if math.Abs(w.Mean()-mean) > 1e-9 {
+		t.Errorf("Mean() = %v, want %v", w.Mean(), mean)
+	}
+	if math.Abs(w.Variance()-wantVariance) > 1e-9 {
+		t.Errorf("Variance() = %v, want %v", w.Variance(), wantVariance)
+	}
+}
+
+func TestWelfordMergeMatchesSinglePass(t *testing.T) {
+	samples := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	whole := &Welford{}
+	for _, s := range samples {
+		whole.Push(s)
+	}
+
+	a, b := &Welford{}, &Welford{}
+	for i, s := range samples {
+		if i < len(samples)/2 {
+			a.Push(s)
+		} else {
+			b.Push(s)
+		}
+	}
+	welford_merge(a, b)
+
+	if a.count != whole.count {
+		t.Errorf("merged count = %d, want %d", a.count, whole.count)
+	}
+	if math.Abs(a.Mean()-whole.Mean()) > 1e-9 {
+		t.Errorf("merged mean = %v, want %v", a.Mean(), whole.Mean())
+	}
+	if math.Abs(a.Variance()-whole.Variance()) > 1e-9 {
+		t.Errorf("merged variance = %v, want %v", a.Variance(), whole.Variance())
+	}
+}
+
+func TestAggregateExecutorResultsSplitsGetPut(t *testing.T) {
+	get := &executor{Workload: Workload{Type: GET}, total: 2, sumLat: 20 * time.Millisecond, maxLat: 15 * time.Millisecond, minLat: 5 * time.Millisecond, histogram: NewHistogram(), variance: &Welford{}}
+	put := &executor{Workload: Workload{Type: PUT}, total: 1, sumLat: 9 * time.Millisecond, maxLat: 9 * time.Millisecond, minLat: 9 * time.Millisecond, histogram: NewHistogram(), variance: &Welford{}}
+
+	ar := aggregate_executor_results([]*executor{get, put})
+
+	if ar.OverallRequests != 3 {
+		t.Errorf("OverallRequests = %d, want 3", ar.OverallRequests)
+	}
+	if ar.GetRequests != 2 || ar.PutRequests != 1 {
+		t.Errorf("GetRequests/PutRequests = %d/%d, want 2/1", ar.GetRequests, ar.PutRequests)
+	}
+	if ar.GetAvgLat != 10*time.Millisecond {
+		t.Errorf("GetAvgLat = %v, want 10ms", ar.GetAvgLat)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// SystemSample is a single point-in-time reading of host and process
+// resource usage, tagged with the monotonic offset from start_time so it
+// can be correlated against the latency/throughput timeline in report().
+type SystemSample struct {
+	OffsetSec   float64 `json:"offset_sec"`
+	Load1       float64 `json:"load1"`
+	Load5       float64 `json:"load5"`
+	Load15      float64 `json:"load15"`
+	CPUPercent  float64 `json:"cpu_percent"`
+	RSSBytes    uint64  `json:"rss_bytes"`
+	NetBytesIn  uint64  `json:"net_bytes_in"`
+	NetBytesOut uint64  `json:"net_bytes_out"`
+}
+
+// SystemSampler polls host and process resource usage on a fixed interval
+// for the duration of a run, so a saturated server can be told apart from a
+// saturated client after the fact.
+type SystemSampler struct {
+	interval time.Duration
+	proc     *process.Process
+	samples  []SystemSample
+	mu       sync.Mutex
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func NewSystemSampler(interval time.Duration) *SystemSampler {
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		log.Println("system sampler: failed to attach to self: ", err)
+	}
+	return &SystemSampler{
+		interval: interval,
+		proc:     proc,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *SystemSampler) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		var lastNet net.IOCountersStat
+		haveLastNet := false
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				sample := SystemSample{OffsetSec: time.Since(start_time).Seconds()}
+
+				if avg, err := load.Avg(); err == nil {
+					sample.Load1 = avg.Load1
+					sample.Load5 = avg.Load5
+					sample.Load15 = avg.Load15
+				}
+
+				if percents, err := cpu.Percent(0, false); err == nil && len(percents) > 0 {
+					sample.CPUPercent = percents[0]
+				}
+
+				if s.proc != nil {
+					if mem, err := s.proc.MemoryInfo(); err == nil {
+						sample.RSSBytes = mem.RSS
+					}
+				}
+
+				if counters, err := net.IOCounters(false); err == nil && len(counters) > 0 {
+					if haveLastNet {
+						sample.NetBytesIn = counters[0].BytesRecv - lastNet.BytesRecv
+						sample.NetBytesOut = counters[0].BytesSent - lastNet.BytesSent
+					}
+					lastNet = counters[0]
+					haveLastNet = true
+				}
+
+				s.mu.Lock()
+				s.samples = append(s.samples, sample)
+				s.mu.Unlock()
+			}
+		}
+	}()
+}
+
+func (s *SystemSampler) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *SystemSampler) Samples() []SystemSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]SystemSample, len(s.samples))
+	copy(out, s.samples)
+	return out
+}
+
+// Peaks returns the peak CPU%, peak RSS and average load1 seen over the
+// whole run, the numbers report() prints to separate "server saturated"
+// from "client saturated" results.
+func (s *SystemSampler) Peaks() (peakCPU float64, peakRSS uint64, avgLoad1 float64) {
+	samples := s.Samples()
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	var loadSum float64
+	for _, sample := range samples {
+		if sample.CPUPercent > peakCPU {
+			peakCPU = sample.CPUPercent
+		}
+		if sample.RSSBytes > peakRSS {
+			peakRSS = sample.RSSBytes
+		}
+		loadSum += sample.Load1
+	}
+	avgLoad1 = loadSum / float64(len(samples))
+	return
+}
+
+func (s *SystemSampler) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	f.WriteString("offset_sec,load1,load5,load15,cpu_percent,rss_bytes,net_bytes_in,net_bytes_out\n")
+	for _, sample := range s.Samples() {
+		f.WriteString(fmt.Sprintf("%f,%f,%f,%f,%f,%d,%d,%d\n",
+			sample.OffsetSec, sample.Load1, sample.Load5, sample.Load15,
+			sample.CPUPercent, sample.RSSBytes, sample.NetBytesIn, sample.NetBytesOut))
+	}
+	return nil
+}
+
+func (s *SystemSampler) WriteResultsSection(f *os.File) {
+	peakCPU, peakRSS, avgLoad1 := s.Peaks()
+	f.WriteString(fmt.Sprintf("\n[system]\n"))
+	f.WriteString(fmt.Sprintf("peak_cpu_percent=%f\n", peakCPU))
+	f.WriteString(fmt.Sprintf("peak_rss_bytes=%d\n", peakRSS))
+	f.WriteString(fmt.Sprintf("avg_load1=%f\n", avgLoad1))
+}
+
+func (s *SystemSampler) WriteJSON(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s.Samples())
+}
+
+// global_sampler runs for the lifetime of the test, started from main()
+// between start_executors() and wait_for_completion() so it covers the
+// entire load-generation window.
+var global_sampler *SystemSampler
+
+const system_sample_interval = 2 * time.Second
+
+func start_system_sampler() {
+	global_sampler = NewSystemSampler(system_sample_interval)
+	global_sampler.Start()
+}
+
+func stop_system_sampler() {
+	if global_sampler != nil {
+		global_sampler.Stop()
+	}
+}